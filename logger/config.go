@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config configures the package's default sink (encoder format, time
+// layout, output destination) and logger-wide behavior (development mode,
+// initial fields). See Configure.
+type Config struct {
+	// Format selects the default sink's encoder: "json" (the default) or
+	// "console" for a human-friendly, colorized format suited to a
+	// terminal.
+	Format string
+	// TimeFormat is a time.Time layout string used to encode timestamps.
+	// Empty keeps the package's historical ISO8601 default.
+	TimeFormat string
+	// Output is "stdout" (the default), "stderr", or a file path.
+	Output string
+	// Development enables stacktraces on Warn and above and makes DPanic
+	// calls panic instead of just logging, matching zap.Development's usual
+	// meaning. Console mode has colored levels whether or not it is set.
+	// It applies to the package-level logger; loggers returned by Named
+	// are not affected.
+	Development bool
+	// InitialFields are attached to every entry logged through the
+	// package-level Debug/Info/... and Ctx* functions and WithContext
+	// loggers. Loggers returned by Named do not carry them.
+	InitialFields map[string]any
+}
+
+// configuredFile is the *os.File backing the default sink's output, if
+// Configure was given a file path. It is closed (and replaced) by the next
+// call to Configure, and by Close.
+var configuredFile *os.File
+
+// Configure replaces the default sink (added at package init) with one
+// built from cfg, and rebuilds the logger-wide options (development mode,
+// initial fields). Other sinks registered via AddCore/AddFileSink/
+// AddSyslogSink are unaffected.
+func Configure(cfg Config) error {
+	if cfg.Format == "" {
+		cfg.Format = "json"
+	}
+
+	encoderConfig := newEncoderConfig()
+	if cfg.TimeFormat != "" {
+		encoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout(cfg.TimeFormat)
+	}
+
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case "json":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case "console":
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return fmt.Errorf("logger: unknown format %q, want \"json\" or \"console\"", cfg.Format)
+	}
+
+	writer, file, err := outputSyncer(cfg.Output)
+	if err != nil {
+		return err
+	}
+
+	core := zapcore.NewCore(encoder, writer, sinkLevel)
+
+	multiCore.remove(defaultCoreHandle)
+	defaultCoreHandle = multiCore.add(core)
+	unsampledCore = core // Configure always replaces the default sink unsampled; re-enable via EnableSampling if needed
+
+	_ = Close() // release the previously configured file output, if any
+	configuredFile = file
+
+	opts := []zap.Option{zap.AddCaller(), zap.AddCallerSkip(1)}
+	if cfg.Development {
+		opts = append(opts, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	}
+	if len(cfg.InitialFields) > 0 {
+		fields := make([]zap.Field, 0, len(cfg.InitialFields))
+		for k, v := range cfg.InitialFields {
+			fields = append(fields, zap.Any(k, v))
+		}
+		// zap.Fields sets log's core to a leveledCore over
+		// multiCore.With(fields); that stays bound to the live multiCore
+		// (see lockedMultiCore.With), so later
+		// AddCore/RemoveCore/EnableSampling/Configure still reach it.
+		opts = append(opts, zap.Fields(fields...))
+	}
+
+	log = zap.New(leveledCore{level: atomicLevel, core: multiCore}, opts...)
+	return nil
+}
+
+// outputSyncer resolves a Config.Output value to a write syncer for the
+// default sink, opening and returning the backing file when output is a
+// path rather than "stdout"/"stderr".
+func outputSyncer(output string) (zapcore.WriteSyncer, *os.File, error) {
+	switch output {
+	case "", "stdout":
+		return zapcore.Lock(os.Stdout), nil, nil
+	case "stderr":
+		return zapcore.Lock(os.Stderr), nil, nil
+	default:
+		f, err := os.OpenFile(output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("logger: opening log output %q: %w", output, err)
+		}
+		return zapcore.Lock(zapcore.AddSync(f)), f, nil
+	}
+}
+
+// Sync flushes any buffered log entries across the default sink and every
+// sink registered via AddCore/AddFileSink/AddSyslogSink.
+func Sync() error {
+	return log.Sync()
+}
+
+// Close releases the file backing the default sink's output, if Configure
+// (or LOG_OUTPUT via Init) pointed it at a file path. It is a no-op
+// otherwise.
+func Close() error {
+	if configuredFile == nil {
+		return nil
+	}
+	f := configuredFile
+	configuredFile = nil
+	return f.Close()
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func resetDefaultCore(t *testing.T) {
+	t.Helper()
+	originalLog := log
+	originalCore, ok := multiCore.cores[defaultCoreHandle]
+	if !ok {
+		t.Fatal("default core handle not found before test")
+	}
+	originalHandle := defaultCoreHandle
+	originalFile := configuredFile
+
+	t.Cleanup(func() {
+		_ = Close()
+		multiCore.remove(defaultCoreHandle)
+		multiCore.mu.Lock()
+		multiCore.cores[originalHandle] = originalCore
+		multiCore.mu.Unlock()
+		defaultCoreHandle = originalHandle
+		configuredFile = originalFile
+		log = originalLog
+	})
+}
+
+func TestConfigureConsoleFormat(t *testing.T) {
+	resetDefaultCore(t)
+
+	// Send the configured console sink to a file so we can assert on the
+	// output Configure's encoder produced without capturing the process's
+	// real stdout.
+	path := filepath.Join(t.TempDir(), "console.log")
+	if err := Configure(Config{Format: "console", Output: path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	Info("hello console")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading log file: %v", err)
+	}
+	if !strings.Contains(string(data), "hello console") {
+		t.Errorf("expected console output to contain the message, got %q", string(data))
+	}
+	if !strings.Contains(string(data), "INFO") {
+		t.Errorf("expected console output to contain a capitalized level, got %q", string(data))
+	}
+}
+
+func TestConfigureUnknownFormat(t *testing.T) {
+	resetDefaultCore(t)
+
+	if err := Configure(Config{Format: "yaml"}); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
+
+func TestConfigureFileOutput(t *testing.T) {
+	resetDefaultCore(t)
+
+	path := filepath.Join(t.TempDir(), "out.log")
+	if err := Configure(Config{Output: path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	Info("hello file")
+	if err := Sync(); err != nil {
+		t.Fatalf("unexpected error syncing: %v", err)
+	}
+	if err := Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading log file: %v", err)
+	}
+	if !strings.Contains(string(data), "hello file") {
+		t.Errorf("expected file output to contain the message, got %q", string(data))
+	}
+
+	var entry map[string]interface{}
+	line := strings.TrimSpace(strings.Split(string(data), "\n")[0])
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Errorf("expected file sink to still be JSON by default: %v", err)
+	}
+}
+
+func TestConfigureInitialFields(t *testing.T) {
+	resetDefaultCore(t)
+
+	var buf bytes.Buffer
+	if err := Configure(Config{InitialFields: map[string]any{"service": "billing"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(newEncoderConfig()), zapcore.AddSync(&buf), atomicLevel)
+	multiCore.remove(defaultCoreHandle)
+	defaultCoreHandle = multiCore.add(core)
+
+	Info("hello fields")
+
+	var entry map[string]interface{}
+	line := strings.TrimSpace(buf.String())
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("failed to parse log line: %v", err)
+	}
+	if entry["service"] != "billing" {
+		t.Errorf("expected initial field 'service' to be 'billing', got %v", entry["service"])
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ctxFieldsKey is the context.Context key under which ContextWith
+// accumulates fields.
+type ctxFieldsKey struct{}
+
+// ScopedLogger is a logger carrying context-derived fields (see
+// ContextWith and WithContext) baked in, so every entry it logs includes
+// them automatically. It embeds *zap.Logger, so its Debug/Info/.../With
+// methods and any other use of the underlying *zap.Logger behave exactly
+// as they would for a logger obtained any other way.
+type ScopedLogger struct {
+	*zap.Logger
+}
+
+// ContextWith returns a copy of ctx carrying fields in addition to any
+// already accumulated on ctx by an earlier call. WithContext and the
+// CtxDebug/CtxInfo/CtxWarn/CtxError helpers pick these fields up
+// automatically; a typical use is to attach a request ID or user ID once
+// at the top of a request and have it flow into every log entry below.
+func ContextWith(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	existing, _ := ctx.Value(ctxFieldsKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, ctxFieldsKey{}, merged)
+}
+
+// fieldsFromContext returns the fields accumulated on ctx via ContextWith,
+// plus trace_id/span_id if ctx carries a valid OpenTelemetry span context.
+func fieldsFromContext(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(ctxFieldsKey{}).([]zap.Field)
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		// Copy before appending so we never mutate the slice stored on ctx.
+		fields = append(fields[:len(fields):len(fields)],
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	return fields
+}
+
+// WithContext returns a ScopedLogger with the fields accumulated on ctx via
+// ContextWith (and, if present, an OpenTelemetry trace_id/span_id) baked
+// in as of this call. This is deliberately a point-in-time snapshot rather
+// than a zapcore.Core wrapper that re-derives fields from ctx on every
+// Write: context.Context values are immutable (ContextWith returns a new
+// ctx rather than mutating the one passed in), so for a single ctx object
+// there is nothing a Write-time re-read could observe that wasn't already
+// present when WithContext was called — a wrapper would cost an extra
+// indirection on every entry for no behavioral difference. What a
+// snapshot does miss is a ScopedLogger being held across a *later*
+// ContextWith call that produces a new, descendant ctx: that new ctx's
+// fields were never passed to this WithContext call, so they can't
+// appear. Call WithContext again on the new ctx (or use
+// CtxDebug/CtxInfo/CtxWarn/CtxError, which take ctx per call and so always
+// read the current one) when that matters.
+//
+// Unlike the package-level Debug/Info/..., a ScopedLogger's
+// Debug/Info/... are called directly (no wrapper frame in between), so
+// the extra zap.AddCallerSkip(1) baked into log to account for that
+// wrapper is undone here; otherwise every entry would report its caller
+// one frame too far up the stack.
+func WithContext(ctx context.Context) *ScopedLogger {
+	return &ScopedLogger{log.WithOptions(zap.AddCallerSkip(-1)).With(fieldsFromContext(ctx)...)}
+}
+
+// CtxDebug logs msg at debug level with the fields accumulated on ctx (see
+// ContextWith), in addition to fields.
+func CtxDebug(ctx context.Context, msg string, fields ...zap.Field) {
+	log.Debug(msg, append(fieldsFromContext(ctx), fields...)...)
+}
+
+// CtxInfo logs msg at info level with the fields accumulated on ctx (see
+// ContextWith), in addition to fields.
+func CtxInfo(ctx context.Context, msg string, fields ...zap.Field) {
+	log.Info(msg, append(fieldsFromContext(ctx), fields...)...)
+}
+
+// CtxWarn logs msg at warn level with the fields accumulated on ctx (see
+// ContextWith), in addition to fields.
+func CtxWarn(ctx context.Context, msg string, fields ...zap.Field) {
+	log.Warn(msg, append(fieldsFromContext(ctx), fields...)...)
+}
+
+// CtxError logs msg at error level with the fields accumulated on ctx (see
+// ContextWith), in addition to fields.
+func CtxError(ctx context.Context, msg string, fields ...zap.Field) {
+	log.Error(msg, append(fieldsFromContext(ctx), fields...)...)
+}
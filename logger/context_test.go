@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func withObservedLog(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	originalLog := log
+	t.Cleanup(func() { log = originalLog })
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	log = zap.New(core)
+	return logs
+}
+
+func TestContextWithAccumulatesFields(t *testing.T) {
+	ctx := context.Background()
+	ctx = ContextWith(ctx, zap.String("request_id", "r1"))
+	ctx = ContextWith(ctx, zap.String("user_id", "u1"))
+
+	logs := withObservedLog(t)
+	CtxInfo(ctx, "handled request")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	ctxMap := entries[0].ContextMap()
+	if ctxMap["request_id"] != "r1" {
+		t.Errorf("expected request_id field r1, got %v", ctxMap["request_id"])
+	}
+	if ctxMap["user_id"] != "u1" {
+		t.Errorf("expected user_id field u1, got %v", ctxMap["user_id"])
+	}
+}
+
+func TestContextWithDoesNotMutateParent(t *testing.T) {
+	base := ContextWith(context.Background(), zap.String("a", "1"))
+	child := ContextWith(base, zap.String("b", "2"))
+
+	logs := withObservedLog(t)
+	CtxInfo(base, "base only")
+	CtxInfo(child, "base and child")
+
+	entries := logs.All()
+	if _, ok := entries[0].ContextMap()["b"]; ok {
+		t.Error("expected base context to not carry field added only to child")
+	}
+	if entries[1].ContextMap()["a"] != "1" || entries[1].ContextMap()["b"] != "2" {
+		t.Errorf("expected child context to carry both fields, got %v", entries[1].ContextMap())
+	}
+}
+
+func TestWithContextBakesInFields(t *testing.T) {
+	ctx := ContextWith(context.Background(), zap.String("request_id", "r1"))
+
+	logs := withObservedLog(t)
+	WithContext(ctx).Info("scoped message")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].ContextMap()["request_id"] != "r1" {
+		t.Errorf("expected request_id field r1, got %v", entries[0].ContextMap()["request_id"])
+	}
+}
+
+func TestWithContextReportsCorrectCaller(t *testing.T) {
+	// withObservedLog doesn't pass AddCaller, so build a log here that
+	// matches the real package logger's construction (see init): AddCaller
+	// plus the AddCallerSkip(1) that accounts for the Debug/Info/...
+	// wrapper frame a ScopedLogger's calls never go through.
+	originalLog := log
+	defer func() { log = originalLog }()
+	core, logs := observer.New(zapcore.DebugLevel)
+	log = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+
+	ctx := context.Background()
+	_, wantFile, callerLine, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("runtime.Caller failed")
+	}
+	WithContext(ctx).Info("scoped message")
+	wantLine := callerLine + 4
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if got := filepath.Base(entries[0].Caller.File); got != filepath.Base(wantFile) {
+		t.Errorf("expected caller file %q, got %q", filepath.Base(wantFile), got)
+	}
+	if entries[0].Caller.Line != wantLine {
+		t.Errorf("expected caller line %d, got %d", wantLine, entries[0].Caller.Line)
+	}
+}
+
+func TestCtxLoggingInjectsTraceFields(t *testing.T) {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logs := withObservedLog(t)
+	CtxInfo(ctx, "traced message")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	ctxMap := entries[0].ContextMap()
+	if ctxMap["trace_id"] != traceID.String() {
+		t.Errorf("expected trace_id %q, got %v", traceID.String(), ctxMap["trace_id"])
+	}
+	if ctxMap["span_id"] != spanID.String() {
+		t.Errorf("expected span_id %q, got %v", spanID.String(), ctxMap["span_id"])
+	}
+}
+
+func TestCtxLoggingWithoutTraceContext(t *testing.T) {
+	logs := withObservedLog(t)
+	CtxInfo(context.Background(), "no trace here")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if _, ok := entries[0].ContextMap()["trace_id"]; ok {
+		t.Error("expected no trace_id field without a span context")
+	}
+}
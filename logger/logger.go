@@ -32,31 +32,88 @@ import (
 )
 
 var (
-	log         *zap.Logger
-	atomicLevel zap.AtomicLevel
+	log               *zap.Logger
+	atomicLevel       zap.AtomicLevel
+	multiCore         *lockedMultiCore
+	defaultCoreHandle int
+	// unsampledCore is the default sink's core without a sampling
+	// wrapper. EnableSampling/DisableSampling swap defaultCoreHandle's
+	// entry in multiCore between this and a zapcore.NewSamplerWithOptions
+	// wrapping it; Configure replaces it outright.
+	unsampledCore zapcore.Core
+	// sinkLevel gates the default sink (built here and rebuilt by
+	// Configure), kept at the most verbose level needed across the root
+	// logger (atomicLevel) and every Named logger (see
+	// refreshSinkLevelLocked). Gating for who actually gets to log at
+	// that level is done by leveledCore (root) and Named's own
+	// leveledCore, not by re-applying atomicLevel at the sink — a sink
+	// gated at the spec default would silently swallow a named logger
+	// lowered below it.
+	sinkLevel zap.AtomicLevel
 )
 
 func init() {
 	atomicLevel = zap.NewAtomicLevel()
 	atomicLevel.SetLevel(zapcore.InfoLevel) // Default to Info level
 
-	encoderConfig := zap.NewProductionEncoderConfig()
-	encoderConfig.TimeKey = "timestamp"
-	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	sinkLevel = zap.NewAtomicLevel()
+	sinkLevel.SetLevel(zapcore.InfoLevel)
 
-	core := zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderConfig),
+	defaultCore := zapcore.NewCore(
+		zapcore.NewJSONEncoder(newEncoderConfig()),
 		zapcore.Lock(os.Stdout),
-		atomicLevel,
+		sinkLevel,
 	)
 
-	log = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+	multiCore = newLockedMultiCore()
+	defaultCoreHandle = multiCore.add(defaultCore)
+	unsampledCore = defaultCore
+	log = zap.New(leveledCore{level: atomicLevel, core: multiCore}, zap.AddCaller(), zap.AddCallerSkip(1))
+}
+
+// newEncoderConfig returns the package's default JSON encoder configuration.
+func newEncoderConfig() zapcore.EncoderConfig {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "timestamp"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return encoderConfig
 }
 
-// Init initializes the logger's level from the environment.
-// It should be called after loading the .env file.
+// Init initializes the logger's level and default sink from the
+// environment. It should be called after loading the .env file.
+//
+// LOG_FORMAT ("json" or "console"), LOG_OUTPUT ("stdout", "stderr", or a
+// file path), and LOG_DEV ("true"/"1" to enable Development mode) are
+// applied via Configure if any of them is set; otherwise the default
+// sink is left at its JSON+ISO8601+stdout behavior. LOG_SPEC, if set,
+// takes precedence over LOG_LEVEL and is parsed by SetLogSpec, allowing
+// per-subsystem overrides (see Named). Otherwise LOG_LEVEL sets the root
+// logger's level via SetLogLevel; loggers returned by Named keep the level
+// the log spec resolves for them. LOG_SAMPLE_TICK/LOG_SAMPLE_FIRST/
+// LOG_SAMPLE_THEREAFTER enable sampling on the default sink via
+// EnableSampling if any of them is set (see applySamplingEnv).
 func Init() {
-	if levelStr := os.Getenv("LOG_LEVEL"); levelStr != "" {
+	if format, output, dev := os.Getenv("LOG_FORMAT"), os.Getenv("LOG_OUTPUT"), os.Getenv("LOG_DEV"); format != "" || output != "" || dev != "" {
+		cfg := Config{
+			Format:      format,
+			Output:      output,
+			Development: dev == "true" || dev == "1",
+		}
+		if err := Configure(cfg); err != nil {
+			Warn("Invalid logger configuration from environment, using default.", zap.Error(err))
+		} else {
+			Info("Logger configured from environment.",
+				zap.String("format", cfg.Format), zap.String("output", output), zap.Bool("development", cfg.Development))
+		}
+	}
+
+	if spec := os.Getenv("LOG_SPEC"); spec != "" {
+		if err := SetLogSpec(spec); err != nil {
+			Warn("Invalid LOG_SPEC provided, using default.", zap.String("value", spec), zap.Error(err))
+		} else {
+			Info("Log spec set from environment.", zap.String("spec", spec))
+		}
+	} else if levelStr := os.Getenv("LOG_LEVEL"); levelStr != "" {
 		if err := SetLogLevel(levelStr); err != nil {
 			Warn("Invalid LOG_LEVEL provided, using default.", zap.String("value", levelStr), zap.Error(err))
 		} else {
@@ -65,6 +122,8 @@ func Init() {
 	} else {
 		Info("Log level is not set, using default 'info'.")
 	}
+
+	applySamplingEnv()
 }
 
 // Debug prints a message at debug level.
@@ -92,30 +151,44 @@ func Fatal(msg string, fields ...zap.Field) {
 	log.Fatal(msg, fields...)
 }
 
-// SetLogLevel sets the log level based on a string.
-func SetLogLevel(level string) error {
-	var newLevel zapcore.Level
+// parseLevel parses a level string using the same vocabulary as SetLogLevel
+// ("debug", "info", "warn"/"warning", "error", "dpanic", "panic", "fatal",
+// case-insensitive), falling back to zapcore's own level parsing.
+func parseLevel(level string) (zapcore.Level, error) {
 	switch strings.ToLower(level) {
 	case "debug":
-		newLevel = zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	case "info":
-		newLevel = zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	case "warn", "warning":
-		newLevel = zapcore.WarnLevel
+		return zapcore.WarnLevel, nil
 	case "error":
-		newLevel = zapcore.ErrorLevel
+		return zapcore.ErrorLevel, nil
 	case "dpanic":
-		newLevel = zapcore.DPanicLevel
+		return zapcore.DPanicLevel, nil
 	case "panic":
-		newLevel = zapcore.PanicLevel
+		return zapcore.PanicLevel, nil
 	case "fatal":
-		newLevel = zapcore.FatalLevel
+		return zapcore.FatalLevel, nil
 	default:
-		if err := newLevel.Set(level); err != nil {
-			return fmt.Errorf("invalid log level string: %s", level)
+		var l zapcore.Level
+		if err := l.Set(level); err != nil {
+			return l, fmt.Errorf("invalid log level string: %s", level)
 		}
+		return l, nil
+	}
+}
+
+// SetLogLevel sets the log level based on a string.
+func SetLogLevel(level string) error {
+	newLevel, err := parseLevel(level)
+	if err != nil {
+		return err
 	}
+	specMu.Lock()
+	defer specMu.Unlock()
 	atomicLevel.SetLevel(newLevel)
+	refreshSinkLevelLocked()
 	return nil
 }
 
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAddCoreFansOutWrites(t *testing.T) {
+	// AddCore/RemoveCore operate on the package-global multiCore that log
+	// is already built on (see init), so exercise them through it rather
+	// than a fresh, unused lockedMultiCore.
+	obsCore, logs := observer.New(zapcore.InfoLevel)
+	handle, err := AddCore(obsCore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer RemoveCore(handle)
+
+	Info("hello from multicore")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 observed entry, got %d", len(entries))
+	}
+	if entries[0].Message != "hello from multicore" {
+		t.Errorf("unexpected message: %q", entries[0].Message)
+	}
+}
+
+func TestRemoveCoreStopsDelivery(t *testing.T) {
+	obsCore, logs := observer.New(zapcore.InfoLevel)
+	handle, err := AddCore(obsCore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	Info("first")
+	RemoveCore(handle)
+	Info("second")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 observed entry after removal, got %d", len(entries))
+	}
+	if entries[0].Message != "first" {
+		t.Errorf("unexpected message: %q", entries[0].Message)
+	}
+}
+
+func TestAddCoreNil(t *testing.T) {
+	if _, err := AddCore(nil); err == nil {
+		t.Fatal("expected error adding a nil core")
+	}
+}
+
+func TestLockedMultiCorePerSinkLevels(t *testing.T) {
+	infoCore, infoLogs := observer.New(zapcore.InfoLevel)
+	debugCore, debugLogs := observer.New(zapcore.DebugLevel)
+
+	mc := newLockedMultiCore(infoCore, debugCore)
+	l := zap.New(mc)
+
+	l.Debug("debug only sink should see this")
+
+	if len(infoLogs.All()) != 0 {
+		t.Errorf("expected info sink to filter out debug entry, got %d entries", len(infoLogs.All()))
+	}
+	if len(debugLogs.All()) != 1 {
+		t.Errorf("expected debug sink to observe the entry, got %d entries", len(debugLogs.All()))
+	}
+
+	if got := mc.Level(); got != zapcore.DebugLevel {
+		t.Errorf("expected multiCore.Level() to report the lowest enabled level (debug), got %v", got)
+	}
+}
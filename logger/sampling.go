@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EnableSampling wraps the default sink in a sampler so that, within each
+// tick window, only the first entries logged with an identical message and
+// level pass through, then only every thereafter-th one after that —
+// bounding log volume for a hot loop without losing the signal that it's
+// happening. Other sinks (AddCore/AddFileSink/AddSyslogSink) are
+// unaffected. See zapcore.NewSamplerWithOptions.
+func EnableSampling(tick time.Duration, first, thereafter int) {
+	multiCore.mu.Lock()
+	defer multiCore.mu.Unlock()
+
+	multiCore.cores[defaultCoreHandle] = zapcore.NewSamplerWithOptions(unsampledCore, tick, first, thereafter)
+}
+
+// DisableSampling restores the default sink to its unsampled form. It is a
+// no-op if sampling is not currently enabled.
+func DisableSampling() {
+	multiCore.mu.Lock()
+	defer multiCore.mu.Unlock()
+
+	multiCore.cores[defaultCoreHandle] = unsampledCore
+}
+
+// applySamplingEnv enables sampling from the LOG_SAMPLE_TICK/
+// LOG_SAMPLE_FIRST/LOG_SAMPLE_THEREAFTER environment variables, if any of
+// them is set. Unset variables fall back to zap's usual sampler defaults
+// (1s tick, 100 first, 100 thereafter).
+func applySamplingEnv() {
+	tickStr, firstStr, thereafterStr := os.Getenv("LOG_SAMPLE_TICK"), os.Getenv("LOG_SAMPLE_FIRST"), os.Getenv("LOG_SAMPLE_THEREAFTER")
+	if tickStr == "" && firstStr == "" && thereafterStr == "" {
+		return
+	}
+
+	tick, first, thereafter, err := parseSamplingEnv(tickStr, firstStr, thereafterStr)
+	if err != nil {
+		Warn("Invalid log sampling configuration from environment, leaving sampling disabled.", zap.Error(err))
+		return
+	}
+
+	EnableSampling(tick, first, thereafter)
+	Info("Log sampling enabled from environment.")
+}
+
+// parseSamplingEnv parses LOG_SAMPLE_TICK/LOG_SAMPLE_FIRST/
+// LOG_SAMPLE_THEREAFTER, defaulting any blank one to zap's usual sampler
+// defaults (1s tick, 100 first, 100 thereafter).
+func parseSamplingEnv(tickStr, firstStr, thereafterStr string) (tick time.Duration, first, thereafter int, err error) {
+	tick, first, thereafter = time.Second, 100, 100
+
+	if tickStr != "" {
+		if tick, err = time.ParseDuration(tickStr); err != nil {
+			return 0, 0, 0, fmt.Errorf("logger: invalid LOG_SAMPLE_TICK %q: %w", tickStr, err)
+		}
+	}
+	if firstStr != "" {
+		if first, err = strconv.Atoi(firstStr); err != nil {
+			return 0, 0, 0, fmt.Errorf("logger: invalid LOG_SAMPLE_FIRST %q: %w", firstStr, err)
+		}
+	}
+	if thereafterStr != "" {
+		if thereafter, err = strconv.Atoi(thereafterStr); err != nil {
+			return 0, 0, 0, fmt.Errorf("logger: invalid LOG_SAMPLE_THEREAFTER %q: %w", thereafterStr, err)
+		}
+	}
+	return tick, first, thereafter, nil
+}
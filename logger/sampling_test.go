@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func withSampledDefaultCore(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+
+	originalCore := unsampledCore
+	originalHandle := defaultCoreHandle
+	t.Cleanup(func() {
+		DisableSampling()
+		multiCore.mu.Lock()
+		multiCore.cores[originalHandle] = originalCore
+		multiCore.mu.Unlock()
+		unsampledCore = originalCore
+	})
+
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	multiCore.mu.Lock()
+	multiCore.cores[defaultCoreHandle] = obsCore
+	multiCore.mu.Unlock()
+	unsampledCore = obsCore
+
+	return logs
+}
+
+func TestEnableSamplingBoundsRepeatedEntries(t *testing.T) {
+	logs := withSampledDefaultCore(t)
+
+	EnableSampling(time.Minute, 2, 5)
+
+	for i := 0; i < 20; i++ {
+		Info("hot loop message")
+	}
+
+	// The first 2 entries pass through, then every 5th thereafter: entries
+	// 1, 2, 7, 12, 17 out of 20 — 5 total.
+	if got := len(logs.All()); got != 5 {
+		t.Errorf("expected sampler to bound repeated entries to 5, got %d", got)
+	}
+}
+
+func TestDisableSamplingRestoresFullVolume(t *testing.T) {
+	logs := withSampledDefaultCore(t)
+
+	EnableSampling(time.Minute, 1, 1000)
+	DisableSampling()
+
+	for i := 0; i < 20; i++ {
+		Info("hot loop message")
+	}
+
+	if got := len(logs.All()); got != 20 {
+		t.Errorf("expected all entries after DisableSampling, got %d", got)
+	}
+}
+
+func TestParseSamplingEnvDefaults(t *testing.T) {
+	tick, first, thereafter, err := parseSamplingEnv("", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tick != time.Second || first != 100 || thereafter != 100 {
+		t.Errorf("expected defaults (1s, 100, 100), got (%v, %d, %d)", tick, first, thereafter)
+	}
+}
+
+func TestParseSamplingEnvInvalid(t *testing.T) {
+	if _, _, _, err := parseSamplingEnv("not-a-duration", "", ""); err == nil {
+		t.Error("expected error for invalid LOG_SAMPLE_TICK")
+	}
+	if _, _, _, err := parseSamplingEnv("", "not-an-int", ""); err == nil {
+		t.Error("expected error for invalid LOG_SAMPLE_FIRST")
+	}
+	if _, _, _, err := parseSamplingEnv("", "", "not-an-int"); err == nil {
+		t.Error("expected error for invalid LOG_SAMPLE_THEREAFTER")
+	}
+}
+
+func BenchmarkHotLoopUnsampled(b *testing.B) {
+	originalCore := unsampledCore
+	originalHandle := defaultCoreHandle
+	defer func() {
+		multiCore.mu.Lock()
+		multiCore.cores[originalHandle] = originalCore
+		multiCore.mu.Unlock()
+		unsampledCore = originalCore
+	}()
+
+	discard := zapcore.NewCore(zapcore.NewJSONEncoder(newEncoderConfig()), zapcore.AddSync(discardWriter{}), atomicLevel)
+	multiCore.mu.Lock()
+	multiCore.cores[defaultCoreHandle] = discard
+	multiCore.mu.Unlock()
+	unsampledCore = discard
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Info("hot loop message")
+	}
+}
+
+func BenchmarkHotLoopSampled(b *testing.B) {
+	originalCore := unsampledCore
+	originalHandle := defaultCoreHandle
+	defer func() {
+		DisableSampling()
+		multiCore.mu.Lock()
+		multiCore.cores[originalHandle] = originalCore
+		multiCore.mu.Unlock()
+		unsampledCore = originalCore
+	}()
+
+	discard := zapcore.NewCore(zapcore.NewJSONEncoder(newEncoderConfig()), zapcore.AddSync(discardWriter{}), atomicLevel)
+	multiCore.mu.Lock()
+	multiCore.cores[defaultCoreHandle] = discard
+	multiCore.mu.Unlock()
+	unsampledCore = discard
+
+	EnableSampling(time.Minute, 10, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Info("hot loop message")
+	}
+}
+
+// discardWriter is a zapcore.WriteSyncer that throws away everything
+// written to it, keeping the benchmarks above focused on core/sampler
+// overhead rather than I/O.
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (discardWriter) Sync() error                 { return nil }
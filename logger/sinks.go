@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AddCore registers an additional zapcore.Core that every subsequent log
+// entry is fanned out to, alongside the default sink and any other
+// registered cores. It returns a handle that can be passed to RemoveCore to
+// stop delivery to c again.
+func AddCore(c zapcore.Core) (handle int, err error) {
+	if c == nil {
+		return 0, fmt.Errorf("logger: cannot add a nil core")
+	}
+	return multiCore.add(c), nil
+}
+
+// RemoveCore stops delivery to the core previously registered with handle.
+// It is a no-op if handle is unknown, e.g. already removed.
+func RemoveCore(handle int) {
+	multiCore.remove(handle)
+}
+
+// AddFileSink registers a core that appends JSON (or, if json is false,
+// console-formatted) log entries at or above level to the file at path,
+// creating it if necessary. It returns a handle that can be passed to
+// RemoveCore to stop delivery to the sink; the file itself is not closed.
+func AddFileSink(path string, level string, json bool) (handle int, err error) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("logger: opening file sink %q: %w", path, err)
+	}
+
+	encoder := encoderFor(json)
+	core := zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(f)), zap.NewAtomicLevelAt(lvl))
+	return AddCore(core)
+}
+
+// encoderFor returns the package's JSON encoder, or its console encoder
+// when json is false.
+func encoderFor(json bool) zapcore.Encoder {
+	if json {
+		return zapcore.NewJSONEncoder(newEncoderConfig())
+	}
+	return zapcore.NewConsoleEncoder(newEncoderConfig())
+}
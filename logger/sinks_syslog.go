@@ -0,0 +1,31 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AddSyslogSink registers a core that forwards log entries at or above
+// level to a syslog daemon, tagged with tag. network/addr are passed to
+// syslog.Dial; an empty network dials the local syslog daemon. It returns a
+// handle that can be passed to RemoveCore to stop the sink.
+func AddSyslogSink(network, addr, tag string, level string, json bool) (handle int, err error) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return 0, err
+	}
+
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return 0, fmt.Errorf("logger: dialing syslog: %w", err)
+	}
+
+	encoder := encoderFor(json)
+	core := zapcore.NewCore(encoder, zapcore.AddSync(w), zap.NewAtomicLevelAt(lvl))
+	return AddCore(core)
+}
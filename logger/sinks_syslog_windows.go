@@ -0,0 +1,11 @@
+//go:build windows
+
+package logger
+
+import "fmt"
+
+// AddSyslogSink is unsupported on Windows, which has no syslog client in the
+// standard library.
+func AddSyslogSink(network, addr, tag string, level string, json bool) (handle int, err error) {
+	return 0, fmt.Errorf("logger: syslog sink is not supported on windows")
+}
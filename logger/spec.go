@@ -0,0 +1,214 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is a named, independently-leveled logger obtained via Named. Its
+// entries carry a "logger" field set to the name, and its level is resolved
+// from (and kept in sync with) the process-wide log spec set by SetLogSpec.
+type Logger struct {
+	*zap.Logger
+	name  string
+	level zap.AtomicLevel
+}
+
+// specRule is a single "name=level" override parsed from a log spec. Rules
+// apply to any logger name matching the dotted prefix, with the longest
+// matching prefix winning.
+type specRule struct {
+	prefix string
+	level  zapcore.Level
+}
+
+var (
+	specMu       sync.Mutex
+	specRaw      string
+	specDefault  = zapcore.InfoLevel
+	specRules    []specRule
+	namedLoggers = map[string]*Logger{}
+)
+
+// Named returns the scoped logger for name, creating it on first use. Its
+// level is resolved from the current log spec (see SetLogSpec) using
+// longest-dotted-prefix matching, e.g. a logger named "mypkg.db" is
+// affected by overrides for both "mypkg.db" and "mypkg", with "mypkg.db"
+// taking precedence. Entries that pass that level check fan out through
+// the same multiCore as the package-level Debug/Info/..., so whatever sinks
+// Configure/AddCore/AddFileSink/AddSyslogSink/EnableSampling have set up
+// for the package apply to named loggers too; Configure's Development and
+// InitialFields settings do not. Creating (or updating, via
+// SetLogSpec) a named logger below the root's level lowers the shared
+// default sink's own threshold to match (see refreshSinkLevelLocked), so
+// that level check is the only one ever applied to its entries.
+func Named(name string) *Logger {
+	specMu.Lock()
+	defer specMu.Unlock()
+
+	if l, ok := namedLoggers[name]; ok {
+		return l
+	}
+
+	level := zap.NewAtomicLevel()
+	level.SetLevel(resolveLevel(name))
+
+	core := leveledCore{level: level, core: multiCore}
+
+	l := &Logger{
+		Logger: zap.New(core, zap.AddCaller()).Named(name),
+		name:   name,
+		level:  level,
+	}
+	namedLoggers[name] = l
+	refreshSinkLevelLocked()
+	return l
+}
+
+// leveledCore gates entries at level before delegating everything else to
+// core. It lets Named give a logger its own level while still writing
+// through the shared multiCore rather than a private sink of its own.
+type leveledCore struct {
+	level zap.AtomicLevel
+	core  zapcore.Core
+}
+
+// Enabled reports whether level is at or above c's own level.
+func (c leveledCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+// With returns a leveledCore for the same level, delegating to core.With.
+func (c leveledCore) With(fields []zapcore.Field) zapcore.Core {
+	return leveledCore{level: c.level, core: c.core.With(fields)}
+}
+
+// Check gates ent on c's own level before delegating to core.Check, so
+// core (and, in turn, its own children) never see an entry this named
+// logger's level has filtered out.
+func (c leveledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.core.Check(ent, ce)
+}
+
+// Write delegates to core.Write.
+func (c leveledCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.core.Write(ent, fields)
+}
+
+// Sync delegates to core.Sync.
+func (c leveledCore) Sync() error {
+	return c.core.Sync()
+}
+
+// SetLogSpec parses and applies a colon-separated level spec, e.g.
+// "info:mypkg=debug:mypkg.db=warn:error". Bare tokens set the default level,
+// applied to the root logger and to any named logger with no matching
+// override; the last bare token wins. "name=level" tokens override the
+// level for loggers whose name matches that dotted prefix (see Named).
+// Empty tokens (e.g. from a leading, trailing, or doubled ":") are ignored.
+func SetLogSpec(spec string) error {
+	def, rules, err := parseLogSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	specMu.Lock()
+	defer specMu.Unlock()
+
+	specRaw = spec
+	specDefault = def
+	specRules = rules
+
+	atomicLevel.SetLevel(def)
+	for name, l := range namedLoggers {
+		l.level.SetLevel(resolveLevel(name))
+	}
+	refreshSinkLevelLocked()
+	return nil
+}
+
+// refreshSinkLevelLocked sets sinkLevel to the most verbose level enabled
+// across the root logger (atomicLevel) and every Named logger, so the
+// shared default sink never re-filters an entry that already passed its
+// logger's own leveledCore gate. Callers must hold specMu.
+func refreshSinkLevelLocked() {
+	level := atomicLevel.Level()
+	for _, l := range namedLoggers {
+		if lvl := l.level.Level(); lvl < level {
+			level = lvl
+		}
+	}
+	sinkLevel.SetLevel(level)
+}
+
+// GetLogSpec returns the spec string last passed to SetLogSpec, or the
+// empty string if it has never been called.
+func GetLogSpec() string {
+	specMu.Lock()
+	defer specMu.Unlock()
+	return specRaw
+}
+
+// parseLogSpec parses a colon-separated level spec into a default level and
+// an ordered set of name=level override rules.
+func parseLogSpec(spec string) (zapcore.Level, []specRule, error) {
+	def := zapcore.InfoLevel
+	var rules []specRule
+
+	for _, token := range strings.Split(spec, ":") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		name, levelStr, isOverride := strings.Cut(token, "=")
+		if isOverride && name == "" {
+			return def, nil, fmt.Errorf("logger: empty name in log spec token %q", token)
+		}
+		if !isOverride {
+			levelStr = token
+		}
+
+		level, err := parseLevel(levelStr)
+		if err != nil {
+			return def, nil, fmt.Errorf("logger: invalid log spec token %q: %w", token, err)
+		}
+
+		if isOverride {
+			rules = append(rules, specRule{prefix: name, level: level})
+		} else {
+			def = level
+		}
+	}
+	return def, rules, nil
+}
+
+// resolveLevel returns the level that applies to a logger named name under
+// the current spec rules, using longest-dotted-prefix match.
+func resolveLevel(name string) zapcore.Level {
+	level := specDefault
+	bestLen := -1
+	for _, r := range specRules {
+		if !matchesPrefix(name, r.prefix) {
+			continue
+		}
+		if len(r.prefix) > bestLen {
+			bestLen = len(r.prefix)
+			level = r.level
+		}
+	}
+	return level
+}
+
+// matchesPrefix reports whether name is prefix itself or is nested under it
+// as a dotted child (e.g. "mypkg" matches "mypkg" and "mypkg.db").
+func matchesPrefix(name, prefix string) bool {
+	return name == prefix || strings.HasPrefix(name, prefix+".")
+}
@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func resetSpecState() {
+	specMu.Lock()
+	defer specMu.Unlock()
+	specRaw = ""
+	specDefault = zapcore.InfoLevel
+	specRules = nil
+	namedLoggers = map[string]*Logger{}
+	atomicLevel.SetLevel(zapcore.InfoLevel)
+	sinkLevel.SetLevel(zapcore.InfoLevel)
+}
+
+func TestParseLogSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expectError bool
+		expectedDef zapcore.Level
+		expectedLen int
+	}{
+		{"single default", "debug", false, zapcore.DebugLevel, 0},
+		{"default and override", "info:mypkg=debug", false, zapcore.InfoLevel, 1},
+		{"last bare wins", "info:mypkg=debug:error", false, zapcore.ErrorLevel, 1},
+		{"multiple overrides", "info:mypkg=debug:mypkg.db=warn", false, zapcore.InfoLevel, 2},
+		{"empty tokens ignored", "info::mypkg=debug:", false, zapcore.InfoLevel, 1},
+		{"empty spec", "", false, zapcore.InfoLevel, 0},
+		{"unknown default level", "bogus", true, zapcore.InfoLevel, 0},
+		{"unknown override level", "mypkg=bogus", true, zapcore.InfoLevel, 0},
+		{"empty override name", "=debug", true, zapcore.InfoLevel, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, rules, err := parseLogSpec(tt.spec)
+			if tt.expectError && err == nil {
+				t.Fatalf("expected error for spec %q, got nil", tt.spec)
+			}
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error for spec %q: %v", tt.spec, err)
+				}
+				if def != tt.expectedDef {
+					t.Errorf("expected default level %v, got %v", tt.expectedDef, def)
+				}
+				if len(rules) != tt.expectedLen {
+					t.Errorf("expected %d rules, got %d", tt.expectedLen, len(rules))
+				}
+			}
+		})
+	}
+}
+
+func TestResolveLevelPrecedence(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	if err := SetLogSpec("info:mypkg=debug:mypkg.db=warn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected zapcore.Level
+	}{
+		{"other", zapcore.InfoLevel},
+		{"mypkg", zapcore.DebugLevel},
+		{"mypkg.http", zapcore.DebugLevel},
+		{"mypkg.db", zapcore.WarnLevel},
+		{"mypkg.db.pool", zapcore.WarnLevel},
+		{"mypkgfoo", zapcore.InfoLevel}, // not a dotted child of "mypkg"
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLevel(tt.name); got != tt.expected {
+				t.Errorf("resolveLevel(%q) = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetLogSpecUpdatesExistingNamedLoggers(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	l := Named("mypkg.db")
+	if l.level.Level() != zapcore.InfoLevel {
+		t.Fatalf("expected initial level info, got %v", l.level.Level())
+	}
+
+	if err := SetLogSpec("info:mypkg.db=warn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.level.Level() != zapcore.WarnLevel {
+		t.Errorf("expected existing named logger to pick up new level warn, got %v", l.level.Level())
+	}
+}
+
+func TestNamedLoweredBelowRootEmitsThroughSharedSink(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	// The shared default sink is gated by sinkLevel (info here), exactly
+	// like the one built in init. Named("mypkg") is lowered to debug, so
+	// sinkLevel must follow it down and its entries must still reach this
+	// sink rather than being re-filtered at the root's (higher) level.
+	originalCore, ok := multiCore.cores[defaultCoreHandle]
+	if !ok {
+		t.Fatal("expected a default core registered at defaultCoreHandle")
+	}
+	defer func() {
+		multiCore.mu.Lock()
+		multiCore.cores[defaultCoreHandle] = originalCore
+		multiCore.mu.Unlock()
+	}()
+
+	obsCore, logs := observer.New(sinkLevel)
+	multiCore.mu.Lock()
+	multiCore.cores[defaultCoreHandle] = obsCore
+	multiCore.mu.Unlock()
+
+	if err := SetLogSpec("info:mypkg=debug"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	Named("mypkg").Debug("debug from a lowered named logger")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 observed entry, got %d", len(entries))
+	}
+	if entries[0].Message != "debug from a lowered named logger" {
+		t.Errorf("unexpected message: %q", entries[0].Message)
+	}
+}
+
+func TestNamedReturnsSameInstance(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	a := Named("svc.billing")
+	b := Named("svc.billing")
+	if a != b {
+		t.Error("expected Named to return the same *Logger instance for a repeated name")
+	}
+}
+
+func TestGetLogSpec(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	if spec := GetLogSpec(); spec != "" {
+		t.Errorf("expected empty spec before SetLogSpec, got %q", spec)
+	}
+
+	const spec = "info:mypkg=debug"
+	if err := SetLogSpec(spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := GetLogSpec(); got != spec {
+		t.Errorf("expected GetLogSpec to return %q, got %q", spec, got)
+	}
+}
+
+func TestSetLogSpecInvalidLeavesStateOnError(t *testing.T) {
+	defer resetSpecState()
+	resetSpecState()
+
+	if err := SetLogSpec("info:mypkg=debug"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SetLogSpec("mypkg=bogus"); err == nil {
+		t.Fatal("expected error for invalid spec")
+	}
+	if got := GetLogSpec(); got != "info:mypkg=debug" {
+		t.Errorf("expected spec to remain unchanged after a failed SetLogSpec, got %q", got)
+	}
+}